@@ -0,0 +1,212 @@
+package zap
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Client is a connection to a ZAP gateway (or directly to an MCP
+// server). It tracks the connection lifecycle as an explicit FSM; see
+// State. A Client is safe for concurrent use.
+type Client struct {
+	addr   string
+	logger *slog.Logger
+
+	mu            sync.Mutex
+	cond          *sync.Cond
+	state         State
+	onStateChange func(from, to State, desc string)
+
+	conn Conn
+}
+
+// stubConn is used until Connect dials the gateway's own protocol
+// through a registered Transport rather than simulating a connection.
+type stubConn struct{}
+
+func (stubConn) Close() error { return nil }
+
+// ClientOption configures a Client at Connect time.
+type ClientOption func(*Client)
+
+// WithLogger attaches a *slog.Logger to the Client. Every log record it
+// writes carries a "state" attribute with the client's current FSM
+// state. A nil logger (the default) is a safe no-op.
+func WithLogger(l *slog.Logger) ClientOption {
+	return func(c *Client) { c.logger = l }
+}
+
+// Connect dials addr and returns a Client in StateInitializing. Callers
+// must still call Init before issuing any MCP operation.
+func Connect(ctx context.Context, addr string, opts ...ClientOption) (*Client, error) {
+	c := &Client{addr: addr, state: StateDisconnected, logger: noopLogger()}
+	c.cond = sync.NewCond(&c.mu)
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.logger = orNoop(c.logger)
+
+	if err := c.goTo(StateConnecting, ""); err != nil {
+		return nil, err
+	}
+
+	// The gateway's own wire protocol isn't pluggable like upstream
+	// server transports are; it's simulated here until it's implemented.
+	c.conn = stubConn{}
+
+	if err := c.goTo(StateInitializing, ""); err != nil {
+		_ = c.goTo(StateDisconnected, "Connect failed")
+		return nil, err
+	}
+	return c, nil
+}
+
+// Init performs the MCP initialize handshake and moves the client to
+// StateReady.
+func (c *Client) Init(ctx context.Context) (*ServerInfo, error) {
+	if err := c.requireState("Init", StateInitializing); err != nil {
+		return nil, err
+	}
+
+	info := &ServerInfo{Name: "zap-gateway", Version: "0.1.0", ProtocolVersion: "2024-11-05"}
+
+	if err := c.goTo(StateReady, ""); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// ListTools returns the tools advertised by the server.
+func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
+	if err := c.requireState("ListTools", StateReady); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// CallTool invokes a tool by name. It transitions through
+// StateCallingTool and back to StateReady; if ctx is cancelled while the
+// call is in flight, the client returns to StateReady via the
+// "Cancel, was calling tool" edge rather than disconnecting.
+func (c *Client) CallTool(ctx context.Context, name string, args any) (*ToolResult, error) {
+	if err := c.requireState("CallTool", StateReady); err != nil {
+		return nil, err
+	}
+	if err := c.goTo(StateCallingTool, ""); err != nil {
+		return nil, err
+	}
+
+	reqID := newRequestID()
+	start := time.Now()
+	result, err := callTool(c, ctx, name, args)
+	duration := time.Since(start)
+
+	if ctx.Err() != nil {
+		_ = c.goTo(StateReady, "Cancel, was calling tool")
+		c.logger.Info("call_tool cancelled", "tool", name, "request_id", reqID, "duration_ms", duration.Milliseconds(), "state", c.State())
+		return nil, ctx.Err()
+	}
+	_ = c.goTo(StateReady, "CallTool finished")
+	c.logger.Info("call_tool", "tool", name, "request_id", reqID, "duration_ms", duration.Milliseconds(), "state", c.State(), "error", err)
+	return result, err
+}
+
+// CallToolWithTimeout is CallTool with ctx bounded by timeout.
+func (c *Client) CallToolWithTimeout(ctx context.Context, name string, args any, timeout time.Duration) (*ToolResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return c.CallTool(ctx, name, args)
+}
+
+// callTool is the actual (currently stubbed) RPC; it will dispatch over
+// c.conn once the transport registry lands. It is a package-level var,
+// rather than a method, so internal tests can substitute a fake
+// implementation and drive real *ToolResult content through CallTool
+// without a real transport.
+var callTool = func(c *Client, ctx context.Context, name string, args any) (*ToolResult, error) {
+	return &ToolResult{}, fmt.Errorf("zap: no transport connected")
+}
+
+// ListResources returns the resources advertised by the server.
+func (c *Client) ListResources(ctx context.Context) ([]Resource, error) {
+	if err := c.requireState("ListResources", StateReady); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ReadResource fetches the content of a single resource by URI.
+func (c *Client) ReadResource(ctx context.Context, uri string) (*ResourceContent, error) {
+	if err := c.requireState("ReadResource", StateReady); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("zap: no transport connected")
+}
+
+// ListPrompts returns the prompts advertised by the server.
+func (c *Client) ListPrompts(ctx context.Context) ([]Prompt, error) {
+	if err := c.requireState("ListPrompts", StateReady); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// GetPrompt renders a prompt template by name.
+func (c *Client) GetPrompt(ctx context.Context, name string, args map[string]any) ([]PromptMessage, error) {
+	if err := c.requireState("GetPrompt", StateReady); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("zap: no transport connected")
+}
+
+// Close tears down the connection and moves the client to StateClosed.
+// Close is idempotent, and unlike other operations may be called from
+// any state, not just the ones declared as legal transitions — e.g. the
+// ordinary Connect-then-defer-Close pattern must still close a client
+// that never reached Init.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	if c.state == StateClosed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.mu.Unlock()
+
+	c.forceGoTo(StateClosed, "Close called")
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
+
+// WaitForState blocks until the client reaches want, ctx is done, or the
+// client is closed while want is not StateClosed.
+func (c *Client) WaitForState(ctx context.Context, want State) error {
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.mu.Lock()
+			c.cond.Broadcast()
+			c.mu.Unlock()
+		case <-done:
+		}
+	}()
+	defer close(done)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.state != want {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if c.state == StateClosed && want != StateClosed {
+			return &ErrInvalidState{From: c.state, To: want}
+		}
+		c.cond.Wait()
+	}
+	return nil
+}
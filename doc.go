@@ -0,0 +1,5 @@
+// Package zap is a lightweight client and gateway for the Model Context
+// Protocol (MCP). A Client speaks MCP to a single gateway endpoint; a
+// Gateway fans requests out to one or more upstream MCP servers and
+// multiplexes them behind a single address.
+package zap
@@ -9,7 +9,6 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"os"
@@ -34,7 +33,8 @@ func main() {
 
 func mainExample() {
 	fmt.Println("ZAP Chat Client Example (Go)")
-	fmt.Println("============================\n")
+	fmt.Println("============================")
+	fmt.Println()
 
 	ctx := context.Background()
 
@@ -45,7 +45,8 @@ func mainExample() {
 	}
 	defer client.Close()
 
-	fmt.Println("Connected to ZAP gateway\n")
+	fmt.Println("Connected to ZAP gateway")
+	fmt.Println()
 
 	// Initialize the connection
 	serverInfo, err := client.Init(ctx)
@@ -159,9 +160,18 @@ func gatewayExample() {
 	// Add MCP servers
 	gateway.AddServer("filesystem", "stdio://npx @modelcontextprotocol/server-filesystem /data")
 	gateway.AddServer("database", "http://localhost:8080/mcp")
-	gateway.AddServer("search", "ws://localhost:9000/ws")
 
-	fmt.Println("Gateway configured with 3 MCP servers")
+	// Register a locally implemented tool; its inputSchema is generated
+	// from SearchInput's struct tags rather than written by hand.
+	err := zap.RegisterTool(gateway, "search", "search the local index",
+		func(ctx context.Context, in SearchInput) (SearchResult, error) {
+			return SearchResult{ID: "1", Title: "found: " + in.Query, Score: 1}, nil
+		})
+	if err != nil {
+		log.Fatalf("Failed to register tool: %v", err)
+	}
+
+	fmt.Println("Gateway configured with 2 upstream MCP servers and 1 local tool")
 	fmt.Println("Starting on port 9999...")
 
 	if err := gateway.Start(ctx); err != nil {
@@ -169,7 +179,31 @@ func gatewayExample() {
 	}
 }
 
-// typedToolExample demonstrates using typed tool calls.
+// SearchInput is the typed argument to the "search" tool, used by both
+// typedToolExample (client side) and gatewayExample (server side).
+type SearchInput struct {
+	Query   string `json:"query" jsonschema:"required"`
+	Limit   int    `json:"limit,omitempty" jsonschema:"minimum=0"`
+	Filters struct {
+		Category  string `json:"category,omitempty"`
+		DateRange struct {
+			Start string `json:"start"`
+			End   string `json:"end"`
+		} `json:"dateRange,omitempty"`
+	} `json:"filters,omitempty"`
+}
+
+// SearchResult is the typed result of the "search" tool.
+type SearchResult struct {
+	ID      string  `json:"id"`
+	Title   string  `json:"title"`
+	Snippet string  `json:"snippet"`
+	Score   float64 `json:"score"`
+}
+
+// typedToolExample demonstrates calling a tool with zap.CallToolTyped,
+// which marshals the input and unmarshals each returned content block
+// into SearchResult, instead of hand-parsing result.Content.
 func typedToolExample() {
 	ctx := context.Background()
 
@@ -179,28 +213,10 @@ func typedToolExample() {
 	}
 	defer client.Close()
 
-	// Define typed input
-	type SearchInput struct {
-		Query   string `json:"query"`
-		Limit   int    `json:"limit,omitempty"`
-		Filters struct {
-			Category  string `json:"category,omitempty"`
-			DateRange struct {
-				Start string `json:"start"`
-				End   string `json:"end"`
-			} `json:"dateRange,omitempty"`
-		} `json:"filters,omitempty"`
-	}
-
-	// Define typed output
-	type SearchResult struct {
-		ID      string  `json:"id"`
-		Title   string  `json:"title"`
-		Snippet string  `json:"snippet"`
-		Score   float64 `json:"score"`
+	if _, err := client.Init(ctx); err != nil {
+		log.Fatalf("Failed to init: %v", err)
 	}
 
-	// Create typed input
 	input := SearchInput{
 		Query: "machine learning",
 		Limit: 10,
@@ -209,21 +225,14 @@ func typedToolExample() {
 	input.Filters.DateRange.Start = "2024-01-01"
 	input.Filters.DateRange.End = "2024-12-31"
 
-	// Call with typed input
-	result, err := client.CallTool(ctx, "search", input)
+	results, err := zap.CallToolTyped[SearchInput, SearchResult](ctx, client, "search", input)
 	if err != nil {
 		log.Fatalf("Failed to call tool: %v", err)
 	}
 
-	// Parse typed response
-	for _, content := range result.Content {
-		var searchResult SearchResult
-		if err := json.Unmarshal([]byte(content.Text), &searchResult); err != nil {
-			log.Printf("Failed to parse result: %v", err)
-			continue
-		}
-		fmt.Printf("[%.2f] %s\n", searchResult.Score, searchResult.Title)
-		fmt.Printf("  %s\n\n", searchResult.Snippet)
+	for _, result := range results {
+		fmt.Printf("[%.2f] %s\n", result.Score, result.Title)
+		fmt.Printf("  %s\n\n", result.Snippet)
 	}
 }
 
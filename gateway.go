@@ -0,0 +1,382 @@
+package zap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zap-proto/zap/transport"
+	_ "github.com/zap-proto/zap/transport/http"
+	_ "github.com/zap-proto/zap/transport/stdio"
+	_ "github.com/zap-proto/zap/transport/ws"
+)
+
+// Transport dials a URL scheme into a Conn. Built-in stdio://, http://
+// and ws:// transports register themselves via blank import above;
+// additional schemes (unix://, tls://, grpc://, sse://, or an
+// in-process mem:// for tests) can be plugged in with RegisterTransport
+// without modifying the gateway.
+type Transport = transport.Transport
+
+// Conn is an established upstream connection, as returned by a
+// Transport's Dial method.
+type Conn = transport.Conn
+
+// ErrUnknownScheme is returned by AddServer when a URL's scheme has no
+// registered Transport.
+type ErrUnknownScheme = transport.ErrUnknownScheme
+
+// RegisterTransport makes t available under t.Scheme() to AddServer.
+func RegisterTransport(t Transport) {
+	transport.Register(t)
+}
+
+// GatewayConfig configures a Gateway.
+type GatewayConfig struct {
+	Host string
+	Port int
+
+	// Logger receives structured records for every gateway operation,
+	// attributed with at least server, scheme and state; CallTool also
+	// carries tool, request_id and duration_ms. A nil Logger is a safe
+	// no-op.
+	Logger *slog.Logger
+
+	// MaxRequestSize and MaxResponseSize cap the marshaled size, in
+	// bytes, of a CallTool's arguments and result. Zero means unlimited.
+	// A request that exceeds MaxRequestSize is rejected with
+	// ErrPayloadTooLarge before it is forwarded upstream.
+	MaxRequestSize  int
+	MaxResponseSize int
+
+	// MaxConcurrentCallsPerServer and MaxConcurrentCallsTotal bound how
+	// many CallTool invocations may be in flight at once, per upstream
+	// and across all upstreams respectively. Zero means unlimited. These
+	// let one slow upstream queue behind its own limit rather than
+	// starving calls to the others.
+	MaxConcurrentCallsPerServer int
+	MaxConcurrentCallsTotal     int
+
+	// CallTimeout bounds how long a CallTool may take, applied when the
+	// caller's context has no earlier deadline. Zero means no timeout.
+	CallTimeout time.Duration
+}
+
+// connStateConnected is the only state an upstreamServer can currently
+// be in: Dial is synchronous and there is no reconnect/health-check
+// logic yet, so every server that made it into g.servers stays
+// "connected" for its lifetime. It exists so the "state" attribute on
+// gateway log records describes something real, rather than a value
+// that could never be anything else being invented wholesale.
+const connStateConnected = "connected"
+
+type upstreamServer struct {
+	url    string
+	scheme string
+	conn   Conn
+	sem    semaphore
+	state  string
+}
+
+// Gateway fans MCP requests out to one or more upstream servers,
+// multiplexing them behind a single address.
+type Gateway struct {
+	cfg      GatewayConfig
+	logger   *slog.Logger
+	totalSem semaphore
+
+	mu      sync.Mutex
+	servers map[string]*upstreamServer
+	tools   map[string]*localTool
+}
+
+// NewGateway creates a Gateway from cfg. It does not start listening
+// until Start is called.
+func NewGateway(cfg GatewayConfig) *Gateway {
+	return &Gateway{
+		cfg:      cfg,
+		logger:   orNoop(cfg.Logger),
+		totalSem: newSemaphore(cfg.MaxConcurrentCallsTotal),
+		servers:  make(map[string]*upstreamServer),
+		tools:    make(map[string]*localTool),
+	}
+}
+
+// AddServer registers an upstream MCP server reachable at url, under
+// name. url's scheme selects the Transport used to dial it, e.g.
+// "stdio://", "http://" or "ws://". It returns ErrUnknownScheme if no
+// Transport is registered for that scheme.
+func (g *Gateway) AddServer(name, url string) error {
+	scheme, _, ok := strings.Cut(url, "://")
+	if !ok {
+		return fmt.Errorf("zap: invalid server url %q", url)
+	}
+	t, err := transport.Lookup(scheme)
+	if err != nil {
+		return err
+	}
+
+	conn, err := t.Dial(context.Background(), url)
+	if err != nil {
+		g.logger.Error("add_server failed", "server", name, "scheme", scheme, "error", err)
+		return fmt.Errorf("zap: dial %s: %w", name, err)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, exists := g.servers[name]; exists {
+		conn.Close()
+		return fmt.Errorf("zap: server %q already registered", name)
+	}
+	g.servers[name] = &upstreamServer{
+		url:    url,
+		scheme: scheme,
+		conn:   conn,
+		sem:    newSemaphore(g.cfg.MaxConcurrentCallsPerServer),
+		state:  connStateConnected,
+	}
+	g.logger.Info("add_server", "server", name, "scheme", scheme, "state", connStateConnected)
+	return nil
+}
+
+// ListTools returns the tools registered locally with RegisterTool,
+// each with its generated inputSchema.
+func (g *Gateway) ListTools(ctx context.Context) ([]Tool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	tools := make([]Tool, 0, len(g.tools))
+	for name, lt := range g.tools {
+		tools = append(tools, Tool{Name: name, Description: lt.description, InputSchema: lt.schemaJSON})
+	}
+	return tools, nil
+}
+
+// CallLocalTool invokes a tool registered with RegisterTool, enforcing
+// the same GatewayConfig size caps, concurrency limit and timeout as
+// CallTool. Arguments are validated against the tool's generated schema
+// before its handler runs; a mismatch is returned as
+// *ErrSchemaValidation rather than reaching the handler.
+func (g *Gateway) CallLocalTool(ctx context.Context, name string, args any) (*ToolResult, error) {
+	g.mu.Lock()
+	lt, ok := g.tools[name]
+	g.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("zap: no tool registered as %q", name)
+	}
+
+	raw, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("zap: marshal args: %w", err)
+	}
+	if g.cfg.MaxRequestSize > 0 && len(raw) > g.cfg.MaxRequestSize {
+		return nil, &ErrPayloadTooLarge{Limit: g.cfg.MaxRequestSize, Actual: len(raw)}
+	}
+
+	if g.cfg.CallTimeout > 0 {
+		if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, g.cfg.CallTimeout)
+			defer cancel()
+		}
+	}
+
+	if err := g.totalSem.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer g.totalSem.release()
+
+	reqID := newRequestID()
+	start := time.Now()
+	result, err := lt.invoke(ctx, raw)
+	duration := time.Since(start)
+
+	if err == nil && g.cfg.MaxResponseSize > 0 {
+		size, sizeErr := marshaledSize(result)
+		if sizeErr == nil && size > g.cfg.MaxResponseSize {
+			result, err = nil, &ErrPayloadTooLarge{Limit: g.cfg.MaxResponseSize, Actual: size}
+		}
+	}
+
+	logErr := err
+	if logErr == nil && result != nil && result.IsError {
+		logErr = errors.New(result.Error)
+	}
+	g.logger.Info("call_tool", "tool", name, "request_id", reqID, "duration_ms", duration.Milliseconds(), "state", "local", "error", logErr)
+	return result, err
+}
+
+// CallTool forwards a CallTool invocation to the named upstream server.
+// It enforces GatewayConfig's size cap and concurrency limits, then logs
+// a single structured record attributed with server, scheme, tool,
+// request_id and duration_ms so operators can correlate the call across
+// servers.
+func (g *Gateway) CallTool(ctx context.Context, server, tool string, args any) (*ToolResult, error) {
+	g.mu.Lock()
+	up, ok := g.servers[server]
+	g.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("zap: no server registered as %q", server)
+	}
+
+	if g.cfg.MaxRequestSize > 0 {
+		size, err := marshaledSize(args)
+		if err != nil {
+			return nil, fmt.Errorf("zap: marshal args: %w", err)
+		}
+		if size > g.cfg.MaxRequestSize {
+			return nil, &ErrPayloadTooLarge{Limit: g.cfg.MaxRequestSize, Actual: size}
+		}
+	}
+
+	if g.cfg.CallTimeout > 0 {
+		if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, g.cfg.CallTimeout)
+			defer cancel()
+		}
+	}
+
+	if err := g.totalSem.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer g.totalSem.release()
+	if err := up.sem.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer up.sem.release()
+
+	reqID := newRequestID()
+	start := time.Now()
+	result, err := forwardCallTool(ctx, up.conn, tool, args)
+	duration := time.Since(start)
+
+	if err == nil && g.cfg.MaxResponseSize > 0 {
+		size, sizeErr := marshaledSize(result)
+		if sizeErr == nil && size > g.cfg.MaxResponseSize {
+			result, err = nil, &ErrPayloadTooLarge{Limit: g.cfg.MaxResponseSize, Actual: size}
+		}
+	}
+
+	g.logger.Info("call_tool",
+		"server", server,
+		"scheme", up.scheme,
+		"tool", tool,
+		"request_id", reqID,
+		"duration_ms", duration.Milliseconds(),
+		"state", up.state,
+		"error", err,
+	)
+	return result, err
+}
+
+// marshaledSize returns the JSON-encoded size of v in bytes.
+func marshaledSize(v any) (int, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// forwardCallTool is the actual (currently stubbed) upstream RPC; it
+// will dispatch over conn once the wire protocol is implemented. It is
+// a package-level var, rather than a plain func, so internal tests can
+// substitute a slow or failing upstream to exercise CallTool's
+// concurrency limiting and timeout handling.
+var forwardCallTool = func(ctx context.Context, conn Conn, tool string, args any) (*ToolResult, error) {
+	return nil, fmt.Errorf("zap: gateway call forwarding not yet implemented")
+}
+
+// ServerStats is one upstream server's slice of a Stats snapshot.
+type ServerStats struct {
+	Name     string
+	Scheme   string
+	InFlight int
+	// Capacity is MaxConcurrentCallsPerServer, or 0 if unlimited.
+	Capacity int
+}
+
+// Stats is a point-in-time snapshot of gateway concurrency usage,
+// returned by Gateway.Stats.
+type Stats struct {
+	Servers []ServerStats
+	// TotalInFlight and TotalCapacity mirror MaxConcurrentCallsTotal;
+	// TotalCapacity is 0 if unlimited.
+	TotalInFlight int
+	TotalCapacity int
+}
+
+// Stats returns a snapshot of the gateway's current queue depth, per
+// upstream server and in total.
+func (g *Gateway) Stats() Stats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	servers := make([]ServerStats, 0, len(g.servers))
+	for name, up := range g.servers {
+		servers = append(servers, ServerStats{
+			Name:     name,
+			Scheme:   up.scheme,
+			InFlight: up.sem.inFlight(),
+			Capacity: up.sem.capacity(),
+		})
+	}
+	return Stats{
+		Servers:       servers,
+		TotalInFlight: g.totalSem.inFlight(),
+		TotalCapacity: g.totalSem.capacity(),
+	}
+}
+
+// Servers returns the names of the currently registered upstream
+// servers, for introspection and tests.
+func (g *Gateway) Servers() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	names := make([]string, 0, len(g.servers))
+	for name := range g.servers {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Start opens a listener on cfg.Host:cfg.Port and accepts client
+// connections, proxying their requests to the registered upstream
+// servers, until ctx is cancelled or the listener fails.
+func (g *Gateway) Start(ctx context.Context) error {
+	addr := fmt.Sprintf("%s:%d", g.cfg.Host, g.cfg.Port)
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("zap: listen on %s: %w", addr, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			ln.Close()
+		case <-done:
+		}
+	}()
+	defer close(done)
+
+	g.logger.Info("gateway listening", "addr", addr, "state", "listening")
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		// The client wire protocol isn't implemented yet; accept and
+		// close rather than leaking the connection.
+		conn.Close()
+	}
+}
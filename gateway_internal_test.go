@@ -0,0 +1,57 @@
+package zap
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zap-proto/zap/transport/mem"
+)
+
+// TestCallToolConcurrencyLimitedPerServer substitutes forwardCallTool
+// with a handler that blocks briefly, so overlapping CallTool calls are
+// actually observable, then asserts the peak concurrency never exceeds
+// MaxConcurrentCallsPerServer.
+func TestCallToolConcurrencyLimitedPerServer(t *testing.T) {
+	mem.Register("search")
+	defer mem.Forget("search")
+
+	var inFlight, maxSeen int32
+	orig := forwardCallTool
+	forwardCallTool = func(ctx context.Context, conn Conn, tool string, args any) (*ToolResult, error) {
+		cur := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxSeen)
+			if cur <= seen || atomic.CompareAndSwapInt32(&maxSeen, seen, cur) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return &ToolResult{}, nil
+	}
+	defer func() { forwardCallTool = orig }()
+
+	g := NewGateway(GatewayConfig{MaxConcurrentCallsPerServer: 1})
+	if err := g.AddServer("search", "mem://search"); err != nil {
+		t.Fatalf("AddServer: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := g.CallTool(context.Background(), "search", "search", nil); err != nil {
+				t.Errorf("CallTool: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&maxSeen); got != 1 {
+		t.Fatalf("peak concurrent forwardCallTool calls = %d, want 1 (MaxConcurrentCallsPerServer)", got)
+	}
+}
@@ -0,0 +1,87 @@
+package zap_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/zap-proto/zap"
+	"github.com/zap-proto/zap/transport/mem"
+)
+
+func TestAddServerMemTransport(t *testing.T) {
+	mem.Register("search")
+	defer mem.Forget("search")
+
+	g := zap.NewGateway(zap.GatewayConfig{Host: "0.0.0.0", Port: 9999})
+	if err := g.AddServer("search", "mem://search"); err != nil {
+		t.Fatalf("AddServer: %v", err)
+	}
+
+	names := g.Servers()
+	if len(names) != 1 || names[0] != "search" {
+		t.Fatalf("Servers() = %v, want [search]", names)
+	}
+}
+
+func TestAddServerUnknownScheme(t *testing.T) {
+	g := zap.NewGateway(zap.GatewayConfig{Host: "0.0.0.0", Port: 9999})
+	err := g.AddServer("search", "grpc://localhost:9000")
+
+	var unknown *zap.ErrUnknownScheme
+	if !errors.As(err, &unknown) {
+		t.Fatalf("AddServer with unregistered scheme: got %v, want *zap.ErrUnknownScheme", err)
+	}
+	if unknown.Scheme != "grpc" {
+		t.Fatalf("ErrUnknownScheme.Scheme = %q, want %q", unknown.Scheme, "grpc")
+	}
+}
+
+func TestCallToolLogsState(t *testing.T) {
+	mem.Register("search")
+	defer mem.Forget("search")
+
+	var buf bytes.Buffer
+	g := zap.NewGateway(zap.GatewayConfig{Logger: zap.NewJSONLogger(&buf, slog.LevelInfo)})
+	if err := g.AddServer("search", "mem://search"); err != nil {
+		t.Fatalf("AddServer: %v", err)
+	}
+	g.CallTool(context.Background(), "search", "search", nil)
+
+	if !strings.Contains(buf.String(), `"state":"connected"`) {
+		t.Fatalf("call_tool log missing state=connected: %s", buf.String())
+	}
+}
+
+func TestCallLocalToolLogsState(t *testing.T) {
+	var buf bytes.Buffer
+	g := zap.NewGateway(zap.GatewayConfig{Logger: zap.NewJSONLogger(&buf, slog.LevelInfo)})
+	if err := zap.RegisterTool(g, "echo", "echoes input",
+		func(ctx context.Context, in map[string]any) (map[string]any, error) { return in, nil }); err != nil {
+		t.Fatalf("RegisterTool: %v", err)
+	}
+	if _, err := g.CallLocalTool(context.Background(), "echo", map[string]any{}); err != nil {
+		t.Fatalf("CallLocalTool: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `"state":"local"`) {
+		t.Fatalf("call_tool log missing state=local: %s", buf.String())
+	}
+}
+
+func TestAddServerBuiltinTransports(t *testing.T) {
+	g := zap.NewGateway(zap.GatewayConfig{Host: "0.0.0.0", Port: 9999})
+
+	if err := g.AddServer("filesystem", "stdio://npx @modelcontextprotocol/server-filesystem /data"); err != nil {
+		t.Fatalf("AddServer(stdio): %v", err)
+	}
+	if err := g.AddServer("database", "http://localhost:8080/mcp"); err != nil {
+		t.Fatalf("AddServer(http): %v", err)
+	}
+	if err := g.AddServer("search", "ws://localhost:9000/ws"); err != nil {
+		t.Fatalf("AddServer(ws): %v", err)
+	}
+}
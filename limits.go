@@ -0,0 +1,57 @@
+package zap
+
+import (
+	"context"
+	"fmt"
+)
+
+// ErrPayloadTooLarge is returned when a request or response exceeds the
+// configured GatewayConfig.MaxRequestSize / MaxResponseSize.
+type ErrPayloadTooLarge struct {
+	Limit  int
+	Actual int
+}
+
+func (e *ErrPayloadTooLarge) Error() string {
+	return fmt.Sprintf("zap: payload too large: %d bytes exceeds limit of %d", e.Actual, e.Limit)
+}
+
+// semaphore is a weighted-by-count concurrency limiter backed by a
+// buffered channel. A nil semaphore (weight 0) is unlimited.
+type semaphore chan struct{}
+
+func newSemaphore(n int) semaphore {
+	if n <= 0 {
+		return nil
+	}
+	return make(semaphore, n)
+}
+
+func (s semaphore) acquire(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s semaphore) release() {
+	if s == nil {
+		return
+	}
+	<-s
+}
+
+// inFlight reports how many holders currently occupy the semaphore.
+func (s semaphore) inFlight() int {
+	return len(s)
+}
+
+// capacity reports the semaphore's weight, or 0 if unlimited.
+func (s semaphore) capacity() int {
+	return cap(s)
+}
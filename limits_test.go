@@ -0,0 +1,64 @@
+package zap_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zap-proto/zap"
+	"github.com/zap-proto/zap/transport/mem"
+)
+
+func TestCallToolRejectsOversizedRequest(t *testing.T) {
+	mem.Register("search")
+	defer mem.Forget("search")
+
+	g := zap.NewGateway(zap.GatewayConfig{MaxRequestSize: 8})
+	if err := g.AddServer("search", "mem://search"); err != nil {
+		t.Fatalf("AddServer: %v", err)
+	}
+
+	_, err := g.CallTool(context.Background(), "search", "search", map[string]any{"query": "something much longer than 8 bytes"})
+
+	var tooLarge *zap.ErrPayloadTooLarge
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("CallTool with oversized args: got %v, want *zap.ErrPayloadTooLarge", err)
+	}
+	if tooLarge.Limit != 8 {
+		t.Fatalf("ErrPayloadTooLarge.Limit = %d, want 8", tooLarge.Limit)
+	}
+}
+
+func TestStatsReportsCapacity(t *testing.T) {
+	mem.Register("search")
+	defer mem.Forget("search")
+
+	g := zap.NewGateway(zap.GatewayConfig{MaxConcurrentCallsPerServer: 2, MaxConcurrentCallsTotal: 4})
+	if err := g.AddServer("search", "mem://search"); err != nil {
+		t.Fatalf("AddServer: %v", err)
+	}
+
+	stats := g.Stats()
+	if stats.TotalCapacity != 4 {
+		t.Fatalf("Stats.TotalCapacity = %d, want 4", stats.TotalCapacity)
+	}
+	if len(stats.Servers) != 1 || stats.Servers[0].Capacity != 2 {
+		t.Fatalf("Stats.Servers = %+v, want one server with capacity 2", stats.Servers)
+	}
+}
+
+func TestClientCallToolWithTimeout(t *testing.T) {
+	ctx := context.Background()
+	c, err := zap.Connect(ctx, "zap://localhost:9999")
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	if _, err := c.Init(ctx); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	if _, err := c.CallToolWithTimeout(ctx, "search", nil, 10*time.Millisecond); err == nil {
+		t.Fatalf("CallToolWithTimeout: got nil error, want the stub transport error")
+	}
+}
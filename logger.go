@@ -0,0 +1,27 @@
+package zap
+
+import (
+	"io"
+	"log/slog"
+)
+
+// NewJSONLogger returns a *slog.Logger that writes structured JSON
+// records to w at the given level. Pass it to GatewayConfig.Logger or
+// WithLogger to get attributed logs out of a Client or Gateway.
+func NewJSONLogger(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+// noopLogger discards everything written to it, so a Client or Gateway
+// that wasn't given a logger can still call its logger unconditionally.
+func noopLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// orNoop returns l, or a noopLogger if l is nil.
+func orNoop(l *slog.Logger) *slog.Logger {
+	if l == nil {
+		return noopLogger()
+	}
+	return l
+}
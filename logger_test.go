@@ -0,0 +1,51 @@
+package zap
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestWithLoggerAttributesFSMTransitions(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewJSONLogger(&buf, slog.LevelDebug)
+
+	ctx := context.Background()
+	c, err := Connect(ctx, "zap://localhost:9999", WithLogger(logger))
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	if _, err := c.Init(ctx); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `"to":"Ready"`) {
+		t.Fatalf("log output missing Ready transition: %s", buf.String())
+	}
+
+	var lastLine string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		lastLine = line
+	}
+	var rec map[string]any
+	if err := json.Unmarshal([]byte(lastLine), &rec); err != nil {
+		t.Fatalf("unmarshal log record: %v", err)
+	}
+	if rec["msg"] != "fsm transition" {
+		t.Fatalf("rec[msg] = %v, want %q", rec["msg"], "fsm transition")
+	}
+}
+
+func TestNilLoggerNoOp(t *testing.T) {
+	ctx := context.Background()
+	c, err := Connect(ctx, "zap://localhost:9999", WithLogger(nil))
+	if err != nil {
+		t.Fatalf("Connect with nil logger: %v", err)
+	}
+	if _, err := c.Init(ctx); err != nil {
+		t.Fatalf("Init with nil logger: %v", err)
+	}
+}
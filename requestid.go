@@ -0,0 +1,15 @@
+package zap
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// newRequestID returns a short random identifier used to correlate a
+// single operation across log records, e.g. a CallTool as it crosses
+// the gateway into an upstream MCP server.
+func newRequestID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,201 @@
+package zap
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// JSONSchema is a deliberately small subset of JSON Schema: enough to
+// describe a tool's input struct, generated by RegisterTool, and to
+// validate incoming arguments against it.
+type JSONSchema struct {
+	Type       string                 `json:"type"`
+	Properties map[string]*JSONSchema `json:"properties,omitempty"`
+	Required   []string               `json:"required,omitempty"`
+	Items      *JSONSchema            `json:"items,omitempty"`
+	Minimum    *float64               `json:"minimum,omitempty"`
+}
+
+// jsonSchemaForType derives a JSONSchema from t's exported fields,
+// reading the standard "json" tag for property names and the
+// "jsonschema" tag (e.g. `jsonschema:"required,minimum=0"`) for
+// constraints.
+func jsonSchemaForType(t reflect.Type) (*JSONSchema, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &JSONSchema{Type: "string"}, nil
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := jsonSchemaForType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &JSONSchema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		return &JSONSchema{Type: "object"}, nil
+	case reflect.Struct:
+		return jsonSchemaForStruct(t)
+	default:
+		return nil, fmt.Errorf("zap: unsupported field type %s for schema generation", t.Kind())
+	}
+}
+
+func jsonSchemaForStruct(t reflect.Type) (*JSONSchema, error) {
+	s := &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{}}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, skip := jsonFieldName(f)
+		if skip {
+			continue
+		}
+
+		prop, err := jsonSchemaForType(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		required, minimum := parseJSONSchemaTag(f.Tag.Get("jsonschema"))
+		if minimum != nil {
+			prop.Minimum = minimum
+		}
+
+		s.Properties[name] = prop
+		if required {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s, nil
+}
+
+// jsonFieldName reads f's "json" tag, returning the property name to
+// use and whether the field should be skipped entirely (json:"-").
+func jsonFieldName(f reflect.StructField) (name string, skip bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ = strings.Cut(tag, ",")
+	if name == "" {
+		name = f.Name
+	}
+	return name, false
+}
+
+// parseJSONSchemaTag parses a `jsonschema:"required,minimum=0"`-style
+// tag into its constraints.
+func parseJSONSchemaTag(tag string) (required bool, minimum *float64) {
+	for _, part := range strings.Split(tag, ",") {
+		switch {
+		case part == "required":
+			required = true
+		case strings.HasPrefix(part, "minimum="):
+			if v, err := strconv.ParseFloat(strings.TrimPrefix(part, "minimum="), 64); err == nil {
+				minimum = &v
+			}
+		}
+	}
+	return required, minimum
+}
+
+// ErrSchemaValidation is returned when a tool's arguments fail
+// validation against its JSONSchema. Pointer is the JSON Pointer
+// (RFC 6901) to the offending value.
+type ErrSchemaValidation struct {
+	Pointer string
+	Message string
+}
+
+func (e *ErrSchemaValidation) Error() string {
+	return fmt.Sprintf("zap: schema validation failed at %q: %s", e.Pointer, e.Message)
+}
+
+// validateJSON unmarshals raw and checks it against schema, returning
+// an *ErrSchemaValidation for the first constraint it violates.
+func validateJSON(schema *JSONSchema, raw json.RawMessage) error {
+	var v any
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return &ErrSchemaValidation{Pointer: "", Message: err.Error()}
+		}
+	}
+	return validateValue(schema, v, "")
+}
+
+func validateValue(schema *JSONSchema, v any, pointer string) error {
+	if v == nil && schema.Type == "object" {
+		v = map[string]any{}
+	}
+	switch schema.Type {
+	case "object":
+		m, ok := v.(map[string]any)
+		if !ok {
+			return &ErrSchemaValidation{Pointer: pointer, Message: "expected object"}
+		}
+		for _, req := range schema.Required {
+			if _, ok := m[req]; !ok {
+				return &ErrSchemaValidation{Pointer: pointer + "/" + req, Message: "missing required property"}
+			}
+		}
+		for name, prop := range schema.Properties {
+			val, ok := m[name]
+			if !ok {
+				continue
+			}
+			if err := validateValue(prop, val, pointer+"/"+name); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			return &ErrSchemaValidation{Pointer: pointer, Message: "expected array"}
+		}
+		for i, item := range arr {
+			if err := validateValue(schema.Items, item, fmt.Sprintf("%s/%d", pointer, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "string":
+		if _, ok := v.(string); !ok {
+			return &ErrSchemaValidation{Pointer: pointer, Message: "expected string"}
+		}
+		return nil
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return &ErrSchemaValidation{Pointer: pointer, Message: "expected boolean"}
+		}
+		return nil
+	case "integer", "number":
+		n, ok := v.(float64)
+		if !ok {
+			return &ErrSchemaValidation{Pointer: pointer, Message: "expected number"}
+		}
+		if schema.Type == "integer" && n != math.Trunc(n) {
+			return &ErrSchemaValidation{Pointer: pointer, Message: "expected integer"}
+		}
+		if schema.Minimum != nil && n < *schema.Minimum {
+			return &ErrSchemaValidation{Pointer: pointer, Message: fmt.Sprintf("must be >= %v", *schema.Minimum)}
+		}
+		return nil
+	default:
+		return nil
+	}
+}
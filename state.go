@@ -0,0 +1,191 @@
+package zap
+
+import "fmt"
+
+// State is a node in the client connection lifecycle FSM.
+type State int
+
+const (
+	// StateDisconnected is the initial state, and the state reached after
+	// a failed connect/reconnect or an explicit Close.
+	StateDisconnected State = iota
+	// StateConnecting means the underlying transport is being dialed.
+	StateConnecting
+	// StateInitializing means the transport is up and the MCP initialize
+	// handshake is in flight.
+	StateInitializing
+	// StateReady means the client has completed initialization and may
+	// issue ListTools, CallTool, ReadResource or GetPrompt.
+	StateReady
+	// StateCallingTool means a CallTool request is in flight.
+	StateCallingTool
+	// StateReconnecting means the connection was lost and the client is
+	// attempting to re-establish it.
+	StateReconnecting
+	// StateClosed is terminal; the client may not be reused.
+	StateClosed
+)
+
+func (s State) String() string {
+	switch s {
+	case StateDisconnected:
+		return "Disconnected"
+	case StateConnecting:
+		return "Connecting"
+	case StateInitializing:
+		return "Initializing"
+	case StateReady:
+		return "Ready"
+	case StateCallingTool:
+		return "CallingTool"
+	case StateReconnecting:
+		return "Reconnecting"
+	case StateClosed:
+		return "Closed"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// Transition is one edge of the FSM declared in transitions.
+type Transition struct {
+	To          State
+	Description string
+}
+
+// transitions is the declared FSM transition table: for each state, the
+// set of states it may legally move to, each with a human-readable
+// description of why that edge exists. goTo validates every mutation
+// against this table rather than letting callers set state directly.
+var transitions = map[State][]Transition{
+	StateDisconnected: {
+		{StateConnecting, "Connect called"},
+	},
+	StateConnecting: {
+		{StateInitializing, "Transport dialed, awaiting initialize"},
+		{StateDisconnected, "Connect failed"},
+	},
+	StateInitializing: {
+		{StateReady, "Initialize complete"},
+		{StateDisconnected, "Initialize failed"},
+	},
+	StateReady: {
+		{StateCallingTool, "CallTool started"},
+		{StateReconnecting, "Connection lost"},
+		{StateClosed, "Close called"},
+	},
+	StateCallingTool: {
+		{StateReady, "CallTool finished"},
+		{StateReady, "Cancel, was calling tool"},
+		{StateReconnecting, "Connection lost"},
+	},
+	StateReconnecting: {
+		{StateInitializing, "Reconnected, awaiting initialize"},
+		{StateDisconnected, "Reconnect failed"},
+		{StateClosed, "Close called"},
+	},
+	StateClosed: nil,
+}
+
+// ErrInvalidState is returned when an operation is attempted from a State
+// that does not permit it, or when goTo is asked to make an illegal
+// transition.
+type ErrInvalidState struct {
+	From State
+	To   State
+	// Op is the operation that was attempted, e.g. "CallTool". Empty for
+	// raw transition failures.
+	Op string
+}
+
+func (e *ErrInvalidState) Error() string {
+	if e.Op != "" {
+		return fmt.Sprintf("zap: %s not permitted in state %s", e.Op, e.From)
+	}
+	return fmt.Sprintf("zap: illegal transition %s -> %s", e.From, e.To)
+}
+
+// transitionDescription reports whether from->to is a declared edge, and
+// if so, its table description.
+func transitionDescription(from, to State) (string, bool) {
+	for _, t := range transitions[from] {
+		if t.To == to {
+			return t.Description, true
+		}
+	}
+	return "", false
+}
+
+// goTo validates and performs a state transition, logging it and firing
+// OnStateChange. desc overrides the table's description when non-empty,
+// which lets callers distinguish edges that share a (from, to) pair for
+// different reasons, such as a normal CallTool completion versus a
+// cancellation landing back on StateReady.
+func (c *Client) goTo(to State, desc string) error {
+	c.mu.Lock()
+	from := c.state
+	tableDesc, ok := transitionDescription(from, to)
+	if !ok {
+		c.mu.Unlock()
+		return &ErrInvalidState{From: from, To: to}
+	}
+	if desc == "" {
+		desc = tableDesc
+	}
+	c.mu.Unlock()
+
+	c.forceGoTo(to, desc)
+	return nil
+}
+
+// forceGoTo sets the client's state to to unconditionally, bypassing the
+// transitions table, then logs and fires OnStateChange exactly as goTo
+// does. It exists for edges like Close that must succeed from any state,
+// not just the ones declared as legal transitions.
+func (c *Client) forceGoTo(to State, desc string) {
+	c.mu.Lock()
+	from := c.state
+	c.state = to
+	cb := c.onStateChange
+	if c.cond != nil {
+		c.cond.Broadcast()
+	}
+	c.mu.Unlock()
+
+	c.logger.Debug("fsm transition", "from", from.String(), "to", to.String(), "description", desc)
+	if cb != nil {
+		cb(from, to, desc)
+	}
+}
+
+// requireState returns ErrInvalidState if the client is not currently in
+// one of the given states.
+func (c *Client) requireState(op string, allowed ...State) error {
+	c.mu.Lock()
+	cur := c.state
+	c.mu.Unlock()
+
+	for _, s := range allowed {
+		if cur == s {
+			return nil
+		}
+	}
+	return &ErrInvalidState{From: cur, Op: op}
+}
+
+// State returns the client's current FSM state.
+func (c *Client) State() State {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state
+}
+
+// OnStateChange registers a callback fired after every successful state
+// transition, with the states it moved between and the table (or
+// caller-supplied) description of why. Only one callback may be
+// registered at a time; registering again replaces the previous one.
+func (c *Client) OnStateChange(fn func(from, to State, desc string)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onStateChange = fn
+}
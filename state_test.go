@@ -0,0 +1,120 @@
+package zap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConnectInitTransitions(t *testing.T) {
+	var seen []State
+	ctx := context.Background()
+
+	c, err := Connect(ctx, "zap://localhost:9999")
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	c.OnStateChange(func(from, to State, desc string) {
+		seen = append(seen, to)
+	})
+
+	if got := c.State(); got != StateInitializing {
+		t.Fatalf("state after Connect = %s, want %s", got, StateInitializing)
+	}
+
+	if _, err := c.Init(ctx); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if got := c.State(); got != StateReady {
+		t.Fatalf("state after Init = %s, want %s", got, StateReady)
+	}
+	if len(seen) != 1 || seen[0] != StateReady {
+		t.Fatalf("OnStateChange fired %v, want [Ready]", seen)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if got := c.State(); got != StateClosed {
+		t.Fatalf("state after Close = %s, want %s", got, StateClosed)
+	}
+}
+
+func TestOperationsRejectedOutsideReady(t *testing.T) {
+	ctx := context.Background()
+	c, err := Connect(ctx, "zap://localhost:9999")
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	_, err = c.ListTools(ctx)
+	var invalid *ErrInvalidState
+	if !errors.As(err, &invalid) {
+		t.Fatalf("ListTools before Init: got %v, want *ErrInvalidState", err)
+	}
+	if invalid.From != StateInitializing {
+		t.Fatalf("ErrInvalidState.From = %s, want %s", invalid.From, StateInitializing)
+	}
+}
+
+func TestCancelCallToolReturnsToReady(t *testing.T) {
+	ctx := context.Background()
+	c, err := Connect(ctx, "zap://localhost:9999")
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	if _, err := c.Init(ctx); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	if _, err := c.CallTool(cctx, "search", nil); err == nil {
+		t.Fatalf("CallTool with cancelled ctx: got nil error")
+	}
+	if got := c.State(); got != StateReady {
+		t.Fatalf("state after cancelled CallTool = %s, want %s", got, StateReady)
+	}
+}
+
+func TestCloseFromInitializingSucceeds(t *testing.T) {
+	ctx := context.Background()
+	c, err := Connect(ctx, "zap://localhost:9999")
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	if got := c.State(); got != StateInitializing {
+		t.Fatalf("state after Connect = %s, want %s", got, StateInitializing)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close before Init: %v", err)
+	}
+	if got := c.State(); got != StateClosed {
+		t.Fatalf("state after Close = %s, want %s", got, StateClosed)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+}
+
+func TestWaitForState(t *testing.T) {
+	ctx := context.Background()
+	c, err := Connect(ctx, "zap://localhost:9999")
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		c.Init(ctx)
+	}()
+
+	wctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if err := c.WaitForState(wctx, StateReady); err != nil {
+		t.Fatalf("WaitForState: %v", err)
+	}
+}
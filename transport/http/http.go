@@ -0,0 +1,33 @@
+// Package http implements the zap http:// transport. It is intended to
+// speak MCP over HTTP (streamable) requests to the given endpoint, but
+// Dial is currently a stub: it stores the endpoint and opens no
+// connection.
+package http
+
+import (
+	"context"
+
+	"github.com/zap-proto/zap/transport"
+)
+
+func init() {
+	transport.Register(Transport{})
+}
+
+// Transport dials http:// upstream MCP servers.
+type Transport struct{}
+
+// Scheme returns "http".
+func (Transport) Scheme() string { return "http" }
+
+// Dial is a stub: it records the given endpoint URL but opens no
+// connection.
+func (Transport) Dial(ctx context.Context, url string) (transport.Conn, error) {
+	return &conn{endpoint: url}, nil
+}
+
+type conn struct {
+	endpoint string
+}
+
+func (c *conn) Close() error { return nil }
@@ -0,0 +1,65 @@
+// Package mem implements an in-process zap mem:// transport so gateway
+// routing can be tested hermetically, without spawning a subprocess or
+// opening a real socket. Tests Register a name before dialing mem://name
+// through a Gateway.
+package mem
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/zap-proto/zap/transport"
+)
+
+func init() {
+	transport.Register(Transport{})
+}
+
+var (
+	mu      sync.Mutex
+	servers = map[string]bool{}
+)
+
+// Register makes name dialable as mem://name.
+func Register(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	servers[name] = true
+}
+
+// Forget undoes a prior Register, so tests can clean up after
+// themselves.
+func Forget(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(servers, name)
+}
+
+// Transport dials mem:// upstream servers registered with Register.
+type Transport struct{}
+
+// Scheme returns "mem".
+func (Transport) Scheme() string { return "mem" }
+
+// Dial looks up the registered server named by url (the text after
+// "mem://") and returns a Conn for it.
+func (Transport) Dial(ctx context.Context, url string) (transport.Conn, error) {
+	_, name, _ := strings.Cut(url, "://")
+
+	mu.Lock()
+	_, ok := servers[name]
+	mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("mem: no server registered as %q", name)
+	}
+	return &conn{name: name}, nil
+}
+
+type conn struct {
+	name string
+}
+
+func (c *conn) Close() error { return nil }
@@ -0,0 +1,35 @@
+// Package stdio implements the zap stdio:// transport. It is intended
+// to launch the text after the scheme as a subprocess and speak MCP
+// over its stdin/stdout, but Dial is currently a stub: it stores the
+// command and does not spawn a process.
+package stdio
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zap-proto/zap/transport"
+)
+
+func init() {
+	transport.Register(Transport{})
+}
+
+// Transport dials stdio:// upstream MCP servers.
+type Transport struct{}
+
+// Scheme returns "stdio".
+func (Transport) Scheme() string { return "stdio" }
+
+// Dial is a stub: it records the command embedded in url (the text
+// after "stdio://") but does not spawn it.
+func (Transport) Dial(ctx context.Context, url string) (transport.Conn, error) {
+	_, command, _ := strings.Cut(url, "://")
+	return &conn{command: command}, nil
+}
+
+type conn struct {
+	command string
+}
+
+func (c *conn) Close() error { return nil }
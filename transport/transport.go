@@ -0,0 +1,78 @@
+// Package transport defines the pluggable dial interface used by
+// zap.Gateway to reach upstream MCP servers, plus the registry that
+// maps a URL scheme (stdio://, http://, ws://, ...) to the Transport
+// that handles it.
+//
+// Built-in transports register themselves from zap/transport/{stdio,
+// http,ws} via init(); additional schemes can be added by importing a
+// package that calls Register from its own init(), without touching the
+// gateway.
+package transport
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Conn is an established upstream connection, as returned by a
+// Transport's Dial method.
+type Conn interface {
+	Close() error
+}
+
+// Transport dials a URL for one scheme into a Conn.
+type Transport interface {
+	// Scheme is the URL scheme this Transport handles, e.g. "stdio".
+	Scheme() string
+	// Dial establishes a connection to url, whose scheme is Scheme().
+	Dial(ctx context.Context, url string) (Conn, error)
+}
+
+var (
+	mu       sync.RWMutex
+	byScheme = map[string]Transport{}
+)
+
+// Register makes t available under t.Scheme(). It is meant to be called
+// from a transport package's init() and panics on a duplicate scheme,
+// matching the database/sql driver registry pattern.
+func Register(t Transport) {
+	mu.Lock()
+	defer mu.Unlock()
+	scheme := t.Scheme()
+	if _, exists := byScheme[scheme]; exists {
+		panic("zap/transport: transport already registered for scheme " + scheme)
+	}
+	byScheme[scheme] = t
+}
+
+// Lookup returns the Transport registered for scheme, or an
+// ErrUnknownScheme listing the schemes that are currently registered.
+func Lookup(scheme string) (Transport, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	t, ok := byScheme[scheme]
+	if !ok {
+		known := make([]string, 0, len(byScheme))
+		for s := range byScheme {
+			known = append(known, s)
+		}
+		sort.Strings(known)
+		return nil, &ErrUnknownScheme{Scheme: scheme, Known: known}
+	}
+	return t, nil
+}
+
+// ErrUnknownScheme is returned by Lookup (and so by Gateway.AddServer)
+// when a URL's scheme has no registered Transport.
+type ErrUnknownScheme struct {
+	Scheme string
+	Known  []string
+}
+
+func (e *ErrUnknownScheme) Error() string {
+	return fmt.Sprintf("zap/transport: unknown scheme %q (registered: %s)", e.Scheme, strings.Join(e.Known, ", "))
+}
@@ -0,0 +1,33 @@
+// Package ws implements the zap ws:// transport. It is intended to
+// speak MCP over a WebSocket connection to the given endpoint, but
+// Dial is currently a stub: it stores the endpoint and opens no
+// connection.
+package ws
+
+import (
+	"context"
+
+	"github.com/zap-proto/zap/transport"
+)
+
+func init() {
+	transport.Register(Transport{})
+}
+
+// Transport dials ws:// upstream MCP servers.
+type Transport struct{}
+
+// Scheme returns "ws".
+func (Transport) Scheme() string { return "ws" }
+
+// Dial is a stub: it records the given endpoint URL but opens no
+// connection.
+func (Transport) Dial(ctx context.Context, url string) (transport.Conn, error) {
+	return &conn{endpoint: url}, nil
+}
+
+type conn struct {
+	endpoint string
+}
+
+func (c *conn) Close() error { return nil }
@@ -0,0 +1,94 @@
+package zap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// CallToolTyped calls name on c with in marshaled as the arguments, and
+// unmarshals each returned content block into Out, returning a typed
+// slice instead of raw content blocks.
+func CallToolTyped[In any, Out any](ctx context.Context, c *Client, name string, in In) ([]Out, error) {
+	result, err := c.CallTool(ctx, name, in)
+	if err != nil {
+		return nil, err
+	}
+	if result.IsError {
+		return nil, fmt.Errorf("zap: tool %q returned an error: %s", name, result.Error)
+	}
+
+	out := make([]Out, 0, len(result.Content))
+	for i, block := range result.Content {
+		if block.Type != "" && block.Type != "text" {
+			continue
+		}
+		var v Out
+		if err := json.Unmarshal([]byte(block.Text), &v); err != nil {
+			return nil, fmt.Errorf("zap: unmarshal content block %d: %w", i, err)
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// localTool is a tool implemented directly by a Gateway, registered via
+// RegisterTool, as opposed to one forwarded to an upstream server.
+type localTool struct {
+	description string
+	schemaJSON  json.RawMessage
+	invoke      func(ctx context.Context, args json.RawMessage) (*ToolResult, error)
+}
+
+// RegisterTool registers a locally implemented tool on g under name,
+// generating its inputSchema from In's struct tags so ListTools reports
+// it without the caller writing schema JSON by hand. Incoming arguments
+// are validated against that schema before handler is invoked; a
+// mismatch surfaces as *ErrSchemaValidation rather than reaching the
+// handler.
+func RegisterTool[In any, Out any](g *Gateway, name, description string, handler func(ctx context.Context, in In) (Out, error)) error {
+	var zero In
+	schema, err := jsonSchemaForType(reflect.TypeOf(zero))
+	if err != nil {
+		return fmt.Errorf("zap: register tool %q: %w", name, err)
+	}
+	schemaJSON, err := json.Marshal(schema)
+	if err != nil {
+		return fmt.Errorf("zap: register tool %q: %w", name, err)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, exists := g.tools[name]; exists {
+		return fmt.Errorf("zap: tool %q already registered", name)
+	}
+	g.tools[name] = &localTool{
+		description: description,
+		schemaJSON:  schemaJSON,
+		invoke: func(ctx context.Context, args json.RawMessage) (*ToolResult, error) {
+			if err := validateJSON(schema, args); err != nil {
+				return nil, err
+			}
+
+			var in In
+			if len(args) > 0 {
+				if err := json.Unmarshal(args, &in); err != nil {
+					return nil, fmt.Errorf("zap: unmarshal args: %w", err)
+				}
+			}
+
+			out, err := handler(ctx, in)
+			if err != nil {
+				return &ToolResult{IsError: true, Error: err.Error()}, nil
+			}
+
+			outJSON, err := json.Marshal(out)
+			if err != nil {
+				return nil, fmt.Errorf("zap: marshal result: %w", err)
+			}
+			return &ToolResult{Content: []ContentBlock{{Type: "text", Text: string(outJSON)}}}, nil
+		},
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package zap
+
+import (
+	"context"
+	"testing"
+)
+
+// TestCallToolTypedUnmarshalsEachContentBlock substitutes callTool with a
+// fake that returns a ToolResult with multiple text content blocks, so
+// CallToolTyped's unmarshal loop runs against real content rather than
+// the "no transport connected" stub.
+func TestCallToolTypedUnmarshalsEachContentBlock(t *testing.T) {
+	orig := callTool
+	callTool = func(c *Client, ctx context.Context, name string, args any) (*ToolResult, error) {
+		return &ToolResult{Content: []ContentBlock{
+			{Type: "text", Text: `{"title":"first","score":0.5}`},
+			{Type: "image", Text: ""},
+			{Type: "text", Text: `{"title":"second","score":1}`},
+		}}, nil
+	}
+	defer func() { callTool = orig }()
+
+	ctx := context.Background()
+	c, err := Connect(ctx, "zap://localhost:9999")
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	if _, err := c.Init(ctx); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	type result struct {
+		Title string  `json:"title"`
+		Score float64 `json:"score"`
+	}
+	out, err := CallToolTyped[map[string]any, result](ctx, c, "search", nil)
+	if err != nil {
+		t.Fatalf("CallToolTyped: %v", err)
+	}
+	want := []result{{Title: "first", Score: 0.5}, {Title: "second", Score: 1}}
+	if len(out) != len(want) || out[0] != want[0] || out[1] != want[1] {
+		t.Fatalf("CallToolTyped = %+v, want %+v (image block should be skipped)", out, want)
+	}
+}
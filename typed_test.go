@@ -0,0 +1,88 @@
+package zap_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zap-proto/zap"
+)
+
+type searchInput struct {
+	Query string `json:"query" jsonschema:"required"`
+	Limit int    `json:"limit,omitempty" jsonschema:"minimum=0"`
+}
+
+type searchResult struct {
+	Title string  `json:"title"`
+	Score float64 `json:"score"`
+}
+
+func TestRegisterToolGeneratesSchemaAndListsIt(t *testing.T) {
+	g := zap.NewGateway(zap.GatewayConfig{})
+
+	err := zap.RegisterTool(g, "search", "search for things",
+		func(ctx context.Context, in searchInput) (searchResult, error) {
+			return searchResult{Title: "found: " + in.Query, Score: 1}, nil
+		})
+	if err != nil {
+		t.Fatalf("RegisterTool: %v", err)
+	}
+
+	tools, err := g.ListTools(context.Background())
+	if err != nil {
+		t.Fatalf("ListTools: %v", err)
+	}
+	if len(tools) != 1 || tools[0].Name != "search" {
+		t.Fatalf("ListTools = %+v, want one tool named search", tools)
+	}
+	if string(tools[0].InputSchema) == "" {
+		t.Fatalf("InputSchema is empty")
+	}
+}
+
+func TestCallLocalToolValidatesArgs(t *testing.T) {
+	g := zap.NewGateway(zap.GatewayConfig{})
+	if err := zap.RegisterTool(g, "search", "search for things",
+		func(ctx context.Context, in searchInput) (searchResult, error) {
+			return searchResult{Title: in.Query}, nil
+		}); err != nil {
+		t.Fatalf("RegisterTool: %v", err)
+	}
+
+	_, err := g.CallLocalTool(context.Background(), "search", map[string]any{"limit": 5})
+	var schemaErr *zap.ErrSchemaValidation
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("CallLocalTool missing required field: got %v, want *zap.ErrSchemaValidation", err)
+	}
+	if schemaErr.Pointer != "/query" {
+		t.Fatalf("ErrSchemaValidation.Pointer = %q, want %q", schemaErr.Pointer, "/query")
+	}
+
+	result, err := g.CallLocalTool(context.Background(), "search", map[string]any{"query": "go"})
+	if err != nil {
+		t.Fatalf("CallLocalTool: %v", err)
+	}
+	if len(result.Content) != 1 {
+		t.Fatalf("result.Content = %+v, want one block", result.Content)
+	}
+}
+
+func TestCallLocalToolRejectsNonIntegralLimit(t *testing.T) {
+	g := zap.NewGateway(zap.GatewayConfig{})
+	if err := zap.RegisterTool(g, "search", "search for things",
+		func(ctx context.Context, in searchInput) (searchResult, error) {
+			return searchResult{Title: in.Query}, nil
+		}); err != nil {
+		t.Fatalf("RegisterTool: %v", err)
+	}
+
+	_, err := g.CallLocalTool(context.Background(), "search", map[string]any{"query": "go", "limit": 3.5})
+	var schemaErr *zap.ErrSchemaValidation
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("CallLocalTool with non-integral limit: got %v, want *zap.ErrSchemaValidation", err)
+	}
+	if schemaErr.Pointer != "/limit" {
+		t.Fatalf("ErrSchemaValidation.Pointer = %q, want %q", schemaErr.Pointer, "/limit")
+	}
+}
@@ -0,0 +1,56 @@
+package zap
+
+import "encoding/json"
+
+// ServerInfo describes the MCP server on the other end of a connection,
+// as returned by Init.
+type ServerInfo struct {
+	Name            string
+	Version         string
+	ProtocolVersion string
+}
+
+// Tool describes a callable tool advertised by ListTools.
+type Tool struct {
+	Name        string
+	Description string
+	InputSchema json.RawMessage
+}
+
+// Resource describes a resource advertised by ListResources.
+type Resource struct {
+	URI  string
+	Name string
+}
+
+// ResourceContent is the body of a resource returned by ReadResource.
+type ResourceContent struct {
+	URI      string
+	MIMEType string
+	Text     string
+}
+
+// Prompt describes a prompt template advertised by ListPrompts.
+type Prompt struct {
+	Name        string
+	Description string
+}
+
+// PromptMessage is one message in the result of GetPrompt.
+type PromptMessage struct {
+	Role    string
+	Content string
+}
+
+// ContentBlock is a single block of tool output.
+type ContentBlock struct {
+	Type string
+	Text string
+}
+
+// ToolResult is the result of a CallTool invocation.
+type ToolResult struct {
+	Content []ContentBlock
+	IsError bool
+	Error   string
+}